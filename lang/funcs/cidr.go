@@ -3,7 +3,11 @@ package funcs
 import (
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"net"
+	"net/netip"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/apparentlymart/go-cidr/cidr"
@@ -31,17 +35,18 @@ var CidrHostFunc = function.New(&function.Spec{
 		if err := gocty.FromCtyValue(args[1], &hostNum); err != nil {
 			return cty.UnknownVal(cty.String), err
 		}
-		_, network, err := net.ParseCIDR(args[0].AsString())
+		prefix, err := netip.ParsePrefix(args[0].AsString())
 		if err != nil {
 			return cty.UnknownVal(cty.String), fmt.Errorf("invalid CIDR expression: %s", err)
 		}
+		prefix = normalizePrefix(prefix)
 
-		ip, err := cidr.Host(network, hostNum)
+		addr, err := cidrHostAddr(prefix, hostNum)
 		if err != nil {
 			return cty.UnknownVal(cty.String), err
 		}
 
-		return cty.StringVal(ip.String()), nil
+		return cty.StringVal(addr.String()), nil
 	},
 })
 
@@ -56,12 +61,42 @@ var CidrNetmaskFunc = function.New(&function.Spec{
 	},
 	Type: function.StaticReturnType(cty.String),
 	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
-		_, network, err := net.ParseCIDR(args[0].AsString())
+		prefix, err := netip.ParsePrefix(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("invalid CIDR expression: %s", err)
+		}
+		prefix = normalizePrefix(prefix)
+
+		maskBytes := net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen())
+		mask, ok := netip.AddrFromSlice(maskBytes)
+		if !ok {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to compute netmask for %s", args[0].AsString())
+		}
+
+		return cty.StringVal(mask.String()), nil
+	},
+})
+
+// CidrCanonicalFunc contructs a function that rewrites an IP address prefix
+// into its canonical form: the RFC 5952 canonical string for an IPv6
+// prefix, or the network-address-masked form for an IPv4 prefix.
+var CidrCanonicalFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		prefix, err := netip.ParsePrefix(args[0].AsString())
 		if err != nil {
 			return cty.UnknownVal(cty.String), fmt.Errorf("invalid CIDR expression: %s", err)
 		}
 
-		return cty.StringVal(net.IP(network.Mask).String()), nil
+		canonical := normalizePrefix(prefix).Masked()
+
+		return cty.StringVal(canonical.String()), nil
 	},
 })
 
@@ -88,33 +123,454 @@ var CidrSubnetFunc = function.New(&function.Spec{
 		if err := gocty.FromCtyValue(args[1], &newbits); err != nil {
 			return cty.UnknownVal(cty.String), err
 		}
-		var netnum int
-		if err := gocty.FromCtyValue(args[2], &netnum); err != nil {
+		netnum := new(big.Int)
+		if err := gocty.FromCtyValue(args[2], netnum); err != nil {
 			return cty.UnknownVal(cty.String), err
 		}
 
-		_, network, err := net.ParseCIDR(args[0].AsString())
+		prefix, err := netip.ParsePrefix(args[0].AsString())
 		if err != nil {
 			return cty.UnknownVal(cty.String), fmt.Errorf("invalid CIDR expression: %s", err)
 		}
+		prefix = normalizePrefix(prefix)
 
-		// For portability with 32-bit systems where the subnet number
-		// will be a 32-bit int, we only allow extension of 32 bits in
-		// one call even if we're running on a 64-bit machine.
-		// (Of course, this is significant only for IPv6.)
-		if newbits > 32 {
-			return cty.UnknownVal(cty.String), fmt.Errorf("may not extend prefix by more than 32 bits")
+		newPrefix, err := cidrSubnetPrefix(prefix, newbits, netnum)
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
 		}
 
-		newNetwork, err := cidr.Subnet(network, newbits, netnum)
+		return cty.StringVal(newPrefix.String()), nil
+	},
+})
+
+// normalizePrefix rewrites an IPv4 address expressed in IPv4-in-IPv6 syntax
+// (e.g. ::ffff:192.168.0.0/112) as the equivalent plain IPv4 prefix, so that
+// callers never have to reason about the ambiguous dual representation.
+func normalizePrefix(prefix netip.Prefix) netip.Prefix {
+	addr := prefix.Addr()
+	if !addr.Is4In6() {
+		return prefix
+	}
+
+	bits := prefix.Bits() - 96
+	if bits < 0 {
+		bits = 0
+	}
+	return netip.PrefixFrom(addr.Unmap(), bits)
+}
+
+// cidrHostAddr calculates the host address reached by counting hostNum
+// addresses into prefix's host portion, with negative values counting
+// backwards from the end of the range.
+func cidrHostAddr(prefix netip.Prefix, hostNum int) (netip.Addr, error) {
+	network := prefix.Masked()
+	addr := network.Addr()
+	addrBits := addr.BitLen()
+	hostBits := addrBits - network.Bits()
+
+	maxHosts := new(big.Int).Lsh(big.NewInt(1), uint(hostBits))
+
+	num := big.NewInt(int64(hostNum))
+	if num.Sign() < 0 {
+		num.Add(num, maxHosts)
+	}
+	if num.Sign() < 0 || num.Cmp(maxHosts) >= 0 {
+		return netip.Addr{}, fmt.Errorf("host number %d does not fit in a prefix with %d host bits", hostNum, hostBits)
+	}
+
+	buf := addr.AsSlice()
+	result := new(big.Int).Add(new(big.Int).SetBytes(buf), num)
+	result.FillBytes(buf)
+
+	out, ok := netip.AddrFromSlice(buf)
+	if !ok {
+		return netip.Addr{}, fmt.Errorf("failed to compute host address")
+	}
+	return out, nil
+}
+
+// cidrSubnetPrefix calculates the subnet numbered netnum among those formed
+// by extending prefix by newbits bits. netnum is taken as a big.Int, rather than
+// a machine int, so that IPv6 layouts needing more than 32 bits of
+// addressing room within the new prefix are representable.
+func cidrSubnetPrefix(prefix netip.Prefix, newbits int, netnum *big.Int) (netip.Prefix, error) {
+	network := prefix.Masked()
+	addr := network.Addr()
+	addrBits := addr.BitLen()
+	newPrefixLen := network.Bits() + newbits
+
+	if newbits < 0 {
+		return netip.Prefix{}, fmt.Errorf("cannot extend prefix by a negative number of bits")
+	}
+	if newPrefixLen > addrBits {
+		return netip.Prefix{}, fmt.Errorf("not enough remaining address space for a subnet with a prefix of %d bits after %d", newbits, network.Bits())
+	}
+
+	maxNetnum := new(big.Int).Lsh(big.NewInt(1), uint(newbits))
+	if netnum.Sign() < 0 || netnum.Cmp(maxNetnum) >= 0 {
+		return netip.Prefix{}, fmt.Errorf("subnet number %s does not fit in a prefix extended by %d bits", netnum, newbits)
+	}
+
+	buf := addr.AsSlice()
+	base := new(big.Int).SetBytes(buf)
+	offset := new(big.Int).Lsh(netnum, uint(addrBits-newPrefixLen))
+	result := new(big.Int).Add(base, offset)
+	result.FillBytes(buf)
+
+	newAddr, ok := netip.AddrFromSlice(buf)
+	if !ok {
+		return netip.Prefix{}, fmt.Errorf("failed to compute subnet address")
+	}
+
+	return netip.PrefixFrom(newAddr, newPrefixLen), nil
+}
+
+// CidrSubnetsFunc contructs a function that calculates a sequence of
+// consecutive subnet prefixes of potentially different sizes within a
+// given IP network address prefix.
+var CidrSubnetsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+	},
+	VarParam: &function.Parameter{
+		Name: "newbits",
+		Type: cty.Number,
+	},
+	Type: function.StaticReturnType(cty.List(cty.String)),
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		_, network, err := net.ParseCIDR(args[0].AsString())
 		if err != nil {
-			return cty.UnknownVal(cty.String), err
+			return cty.UnknownVal(retType), fmt.Errorf("invalid CIDR expression: %s", err)
+		}
+
+		startPrefixLen, addrBits := network.Mask.Size()
+
+		newbits := make([]int, len(args)-1)
+		maxPrefixLen := startPrefixLen
+		for i, arg := range args[1:] {
+			var newbit int
+			if err := gocty.FromCtyValue(arg, &newbit); err != nil {
+				return cty.UnknownVal(retType), err
+			}
+			if newbit < 0 {
+				return cty.UnknownVal(retType), fmt.Errorf("negative newbits value at index %d", i)
+			}
+
+			childPrefixLen := startPrefixLen + newbit
+			if childPrefixLen > addrBits {
+				return cty.UnknownVal(retType), fmt.Errorf("netmask extension of %d bits at index %d overflows the %d-bit address of %s", newbit, i, addrBits, args[0].AsString())
+			}
+
+			newbits[i] = newbit
+			if childPrefixLen > maxPrefixLen {
+				maxPrefixLen = childPrefixLen
+			}
+		}
+
+		// cursor tracks the next free address, counted in units of the
+		// smallest subnet we were asked for (maxPrefixLen), so that each
+		// subnet can be aligned to a boundary matching its own size.
+		cursor := new(big.Int)
+		retVals := make([]cty.Value, len(newbits))
+		for i, newbit := range newbits {
+			childPrefixLen := startPrefixLen + newbit
+			unitSize := new(big.Int).Lsh(big.NewInt(1), uint(maxPrefixLen-childPrefixLen))
+
+			if rem := new(big.Int).Mod(cursor, unitSize); rem.Sign() != 0 {
+				cursor.Add(cursor, new(big.Int).Sub(unitSize, rem))
+			}
+
+			netnum := new(big.Int).Div(cursor, unitSize)
+			if !netnum.IsInt64() {
+				return cty.UnknownVal(retType), fmt.Errorf("not enough address space to lay out %d subnets of the requested sizes in %s", len(newbits), args[0].AsString())
+			}
+
+			newNetwork, err := cidr.Subnet(network, newbit, int(netnum.Int64()))
+			if err != nil {
+				return cty.UnknownVal(retType), err
+			}
+			retVals[i] = cty.StringVal(newNetwork.String())
+
+			cursor.Add(cursor, unitSize)
+		}
+
+		totalUnits := new(big.Int).Lsh(big.NewInt(1), uint(maxPrefixLen-startPrefixLen))
+		if cursor.Cmp(totalUnits) > 0 {
+			return cty.UnknownVal(retType), fmt.Errorf("not enough address space to lay out %d subnets of the requested sizes in %s", len(newbits), args[0].AsString())
+		}
+
+		if len(retVals) == 0 {
+			return cty.ListValEmpty(cty.String), nil
+		}
+		return cty.ListVal(retVals), nil
+	},
+})
+
+// CidrContainsFunc contructs a function that checks whether a given IP
+// address or CIDR prefix is entirely contained within another CIDR prefix.
+var CidrContainsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+		{
+			Name: "ip",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		_, network, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), fmt.Errorf("invalid CIDR expression: %s", err)
+		}
+
+		containedIP, containedNetwork, err := net.ParseCIDR(args[1].AsString())
+		if err != nil {
+			containedIP = net.ParseIP(args[1].AsString())
+			if containedIP == nil {
+				return cty.UnknownVal(cty.Bool), fmt.Errorf("invalid IP address or CIDR expression: %s", args[1].AsString())
+			}
+			containedNetwork = nil
+		}
+
+		if (network.IP.To4() == nil) != (containedIP.To4() == nil) {
+			return cty.UnknownVal(cty.Bool), fmt.Errorf("cannot compare an IPv4 address or prefix with an IPv6 address or prefix")
+		}
+
+		if !network.Contains(containedIP) {
+			return cty.False, nil
+		}
+
+		if containedNetwork != nil {
+			networkOnes, _ := network.Mask.Size()
+			containedOnes, _ := containedNetwork.Mask.Size()
+			if containedOnes < networkOnes {
+				return cty.False, nil
+			}
+		}
+
+		return cty.True, nil
+	},
+})
+
+// CidrOverlapFunc contructs a function that checks whether two given CIDR
+// prefixes share any addresses in common.
+var CidrOverlapFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "a",
+			Type: cty.String,
+		},
+		{
+			Name: "b",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		_, networkA, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), fmt.Errorf("invalid CIDR expression: %s", err)
+		}
+		_, networkB, err := net.ParseCIDR(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), fmt.Errorf("invalid CIDR expression: %s", err)
+		}
+
+		if (networkA.IP.To4() == nil) != (networkB.IP.To4() == nil) {
+			return cty.UnknownVal(cty.Bool), fmt.Errorf("cannot compare an IPv4 prefix with an IPv6 prefix")
 		}
 
-		return cty.StringVal(newNetwork.String()), nil
+		return cty.BoolVal(networksOverlap(networkA, networkB)), nil
 	},
 })
 
+// CidrMergeFunc contructs a function that coalesces a list of IP address
+// prefixes in CIDR notation into the minimal set of prefixes that describes
+// the same addresses, merging adjacent and overlapping prefixes where
+// possible.
+var CidrMergeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefixes",
+			Type: cty.List(cty.String),
+		},
+	},
+	Type: function.StaticReturnType(cty.List(cty.String)),
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		var networks []*net.IPNet
+		for it := args[0].ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			_, network, err := net.ParseCIDR(v.AsString())
+			if err != nil {
+				return cty.UnknownVal(retType), fmt.Errorf("invalid CIDR expression: %s", err)
+			}
+			networks = append(networks, network)
+		}
+
+		if len(networks) == 0 {
+			return cty.ListValEmpty(cty.String), nil
+		}
+
+		var v4, v6 []*net.IPNet
+		for _, network := range networks {
+			if network.IP.To4() != nil {
+				v4 = append(v4, network)
+			} else {
+				v6 = append(v6, network)
+			}
+		}
+
+		merged := append(mergeCidrs(v4), mergeCidrs(v6)...)
+
+		vals := make([]cty.Value, len(merged))
+		for i, network := range merged {
+			vals[i] = cty.StringVal(network.String())
+		}
+		return cty.ListVal(vals), nil
+	},
+})
+
+// networksOverlap returns true if the two networks share any address in
+// common, determined by comparing the first min(maskA, maskB) bits of their
+// network addresses.
+func networksOverlap(a, b *net.IPNet) bool {
+	aOnes, _ := a.Mask.Size()
+	bOnes, _ := b.Mask.Size()
+	bits := aOnes
+	if bOnes < bits {
+		bits = bOnes
+	}
+
+	aIP := a.IP.To16()
+	bIP := b.IP.To16()
+	if a.IP.To4() != nil {
+		aIP = a.IP.To4()
+		bIP = b.IP.To4()
+	}
+
+	for i := 0; i < bits; i++ {
+		byteIdx := i / 8
+		bitIdx := uint(7 - i%8)
+		aBit := aIP[byteIdx] >> bitIdx & 1
+		bBit := bIP[byteIdx] >> bitIdx & 1
+		if aBit != bBit {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeCidrs coalesces a list of same-family prefixes into the minimal
+// covering set, repeatedly pairing up adjacent equal-length prefixes whose
+// common supernet covers them exactly until no more merges are possible.
+func mergeCidrs(networks []*net.IPNet) []*net.IPNet {
+	if len(networks) == 0 {
+		return nil
+	}
+
+	for {
+		sort.Slice(networks, func(i, j int) bool {
+			oi, _ := networks[i].Mask.Size()
+			oj, _ := networks[j].Mask.Size()
+			if c := bytesCompare(networks[i].IP, networks[j].IP); c != 0 {
+				return c < 0
+			}
+			return oi < oj
+		})
+
+		merged := false
+		result := make([]*net.IPNet, 0, len(networks))
+		for i := 0; i < len(networks); i++ {
+			if i+1 < len(networks) {
+				if supernet, ok := tryMergePair(networks[i], networks[i+1]); ok {
+					result = append(result, supernet)
+					i++
+					merged = true
+					continue
+				}
+			}
+
+			if isSubsumed(networks[i], result) {
+				// Dropping a covered prefix can make its neighbours
+				// adjacent, so another pass is needed.
+				merged = true
+				continue
+			}
+
+			result = append(result, networks[i])
+		}
+
+		networks = result
+		if !merged {
+			return networks
+		}
+	}
+}
+
+// tryMergePair merges a and b into their common supernet if they are
+// equal-length adjacent prefixes whose supernet covers exactly their union.
+func tryMergePair(a, b *net.IPNet) (*net.IPNet, bool) {
+	aOnes, _ := a.Mask.Size()
+	bOnes, _ := b.Mask.Size()
+	if aOnes != bOnes || aOnes == 0 {
+		return nil, false
+	}
+
+	supernetOnes := aOnes - 1
+	_, supernet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", a.IP.String(), supernetOnes))
+	if err != nil {
+		return nil, false
+	}
+
+	if !supernet.IP.Equal(a.IP.Mask(supernet.Mask)) {
+		return nil, false
+	}
+	if !supernet.Contains(b.IP) || !b.IP.Mask(supernet.Mask).Equal(supernet.IP) {
+		return nil, false
+	}
+	// a and b must be the two distinct halves of supernet.
+	if a.IP.Equal(b.IP) {
+		return nil, false
+	}
+
+	return supernet, true
+}
+
+// isSubsumed reports whether network is already entirely contained within
+// one of the networks already selected for the result.
+func isSubsumed(network *net.IPNet, in []*net.IPNet) bool {
+	ones, _ := network.Mask.Size()
+	for _, other := range in {
+		otherOnes, _ := other.Mask.Size()
+		if otherOnes <= ones && other.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// bytesCompare compares two byte slices of IPs lexically, normalizing
+// both to 16 bytes first so IPv4 and IPv4-in-IPv6 forms sort consistently.
+func bytesCompare(a, b net.IP) int {
+	a16 := a.To16()
+	b16 := b.To16()
+	for i := range a16 {
+		if a16[i] != b16[i] {
+			if a16[i] < b16[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
 // RDnsHostFunc contructs a function that reverses the octets or nibbles of
 // single a given IPv4 or IPv6 address.
 var RDnsHostFunc = function.New(&function.Spec{
@@ -126,22 +582,20 @@ var RDnsHostFunc = function.New(&function.Spec{
 	},
 	Type: function.StaticReturnType(cty.String),
 	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
-		// do thing
-
-		ip := net.ParseIP(args[0].AsString())
-		if ip == nil {
-			return cty.UnknownVal(cty.String), fmt.Errorf("invalid IPv4 or IPv6 address: %s", err)
+		addrStr := args[0].AsString()
+		addr, err := netip.ParseAddr(addrStr)
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("invalid IPv4 or IPv6 address: %s", addrStr)
 		}
+		addr = addr.Unmap()
 
-		if ip4 := ip.To4(); ip4 != nil {
-			for i := len(ip4)/2 - 1; i >= 0; i-- {
-				opp := len(ip4) - 1 - i
-				ip4[i], ip4[opp] = ip4[opp], ip4[i]
-			}
-			return cty.StringVal(fmt.Sprintf("%s.in-addr.arpa.", ip4.String())), nil
+		if addr.Is4() {
+			octets := addr.As4()
+			return cty.StringVal(fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa.", octets[3], octets[2], octets[1], octets[0])), nil
 		}
 
-		nibs := hex.EncodeToString(ip.To16())
+		bytes := addr.As16()
+		nibs := hex.EncodeToString(bytes[:])
 
 		rev := make([]string, len(nibs))
 		for i, c := range nibs {
@@ -152,6 +606,172 @@ var RDnsHostFunc = function.New(&function.Spec{
 	},
 })
 
+// IPFromRDnsFunc contructs a function that parses a reverse-DNS host name,
+// in either the in-addr.arpa or ip6.arpa form produced by RDnsHostFunc,
+// back into the canonical IPv4 or IPv6 address it names.
+var IPFromRDnsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "name",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		name := args[0].AsString()
+		trimmed := strings.TrimSuffix(name, ".")
+
+		switch {
+		case strings.HasSuffix(trimmed, ".in-addr.arpa"):
+			labels := strings.Split(strings.TrimSuffix(trimmed, ".in-addr.arpa"), ".")
+			if len(labels) != 4 {
+				return cty.UnknownVal(cty.String), fmt.Errorf("%q is not a full in-addr.arpa host name", name)
+			}
+
+			var octets [4]byte
+			for i, label := range labels {
+				n, err := strconv.Atoi(label)
+				if err != nil || n < 0 || n > 255 {
+					return cty.UnknownVal(cty.String), fmt.Errorf("invalid octet %q in %s", label, name)
+				}
+				octets[3-i] = byte(n)
+			}
+
+			return cty.StringVal(netip.AddrFrom4(octets).String()), nil
+
+		case strings.HasSuffix(trimmed, ".ip6.arpa"):
+			labels := strings.Split(strings.TrimSuffix(trimmed, ".ip6.arpa"), ".")
+			if len(labels) != 32 {
+				return cty.UnknownVal(cty.String), fmt.Errorf("%q is not a full ip6.arpa host name", name)
+			}
+
+			nibs := make([]byte, 32)
+			for i, label := range labels {
+				if len(label) != 1 {
+					return cty.UnknownVal(cty.String), fmt.Errorf("invalid nibble %q in %s", label, name)
+				}
+				nibs[31-i] = label[0]
+			}
+
+			raw, err := hex.DecodeString(string(nibs))
+			if err != nil {
+				return cty.UnknownVal(cty.String), fmt.Errorf("invalid nibble in %s", name)
+			}
+			addr, ok := netip.AddrFromSlice(raw)
+			if !ok {
+				return cty.UnknownVal(cty.String), fmt.Errorf("invalid address in %s", name)
+			}
+
+			return cty.StringVal(addr.String()), nil
+
+		default:
+			return cty.UnknownVal(cty.String), fmt.Errorf("%q is not an in-addr.arpa or ip6.arpa host name", name)
+		}
+	},
+})
+
+// RDnsZoneFunc contructs a function that, given a CIDR prefix, returns the
+// reverse-DNS zone names that would need to be delegated in order to host
+// PTR records for every address in that prefix. When the prefix doesn't
+// fall on an octet (IPv4) or nibble (IPv6) boundary, more than one zone is
+// required to cover it.
+var RDnsZoneFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.List(cty.String)),
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		prefix, err := netip.ParsePrefix(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), fmt.Errorf("invalid CIDR expression: %s", err)
+		}
+		network := normalizePrefix(prefix).Masked()
+
+		var zones []string
+		if network.Addr().Is4() {
+			zones = rdnsZonesV4(network)
+		} else {
+			zones = rdnsZonesV6(network)
+		}
+
+		vals := make([]cty.Value, len(zones))
+		for i, zone := range zones {
+			vals[i] = cty.StringVal(zone)
+		}
+		return cty.ListVal(vals), nil
+	},
+})
+
+// rdnsZonesV4 returns the in-addr.arpa zone names needed to delegate PTR
+// records for network, rounding its prefix length up to the nearest octet
+// boundary and enumerating every zone within that rounded-up range.
+func rdnsZonesV4(network netip.Prefix) []string {
+	prefixLen := network.Bits()
+	zoneLen := prefixLen
+	if rem := zoneLen % 8; rem != 0 {
+		zoneLen += 8 - rem
+	}
+	if zoneLen == 0 {
+		zoneLen = 8
+	}
+
+	numOctets := zoneLen / 8
+	count := 1 << uint(zoneLen-prefixLen)
+
+	octets := network.Addr().As4()
+	zones := make([]string, count)
+	for i := 0; i < count; i++ {
+		cur := octets
+		cur[numOctets-1] += byte(i)
+
+		labels := make([]string, numOctets)
+		for j := 0; j < numOctets; j++ {
+			labels[j] = strconv.Itoa(int(cur[numOctets-1-j]))
+		}
+		zones[i] = strings.Join(labels, ".") + ".in-addr.arpa."
+	}
+	return zones
+}
+
+// rdnsZonesV6 returns the ip6.arpa zone names needed to delegate PTR
+// records for network, rounding its prefix length up to the nearest nibble
+// boundary and enumerating every zone within that rounded-up range.
+func rdnsZonesV6(network netip.Prefix) []string {
+	prefixLen := network.Bits()
+	zoneLen := prefixLen
+	if rem := zoneLen % 4; rem != 0 {
+		zoneLen += 4 - rem
+	}
+	if zoneLen == 0 {
+		zoneLen = 4
+	}
+
+	numNibbles := zoneLen / 4
+	count := 1 << uint(zoneLen-prefixLen)
+
+	addrBytes := network.Addr().As16()
+	nibs := []byte(hex.EncodeToString(addrBytes[:]))
+
+	zones := make([]string, count)
+	for i := 0; i < count; i++ {
+		cur := make([]byte, len(nibs))
+		copy(cur, nibs)
+
+		val, _ := strconv.ParseInt(string(cur[numNibbles-1]), 16, 64)
+		cur[numNibbles-1] = []byte(strconv.FormatInt(val+int64(i), 16))[0]
+
+		labels := make([]string, numNibbles)
+		for j := 0; j < numNibbles; j++ {
+			labels[j] = string(cur[numNibbles-1-j])
+		}
+		zones[i] = strings.Join(labels, ".") + ".ip6.arpa."
+	}
+	return zones
+}
+
 // CidrHost calculates a full host IP address within a given IP network address prefix.
 func CidrHost(prefix, hostnum cty.Value) (cty.Value, error) {
 	return CidrHostFunc.Call([]cty.Value{prefix, hostnum})
@@ -167,7 +787,51 @@ func CidrSubnet(prefix, newbits, netnum cty.Value) (cty.Value, error) {
 	return CidrSubnetFunc.Call([]cty.Value{prefix, newbits, netnum})
 }
 
+// CidrCanonical rewrites an IP address prefix into its canonical form.
+func CidrCanonical(prefix cty.Value) (cty.Value, error) {
+	return CidrCanonicalFunc.Call([]cty.Value{prefix})
+}
+
+// CidrSubnets calculates a sequence of consecutive subnet prefixes, of
+// potentially different sizes, within a given IP network address prefix.
+func CidrSubnets(prefix cty.Value, newbits ...cty.Value) (cty.Value, error) {
+	args := make([]cty.Value, 0, len(newbits)+1)
+	args = append(args, prefix)
+	args = append(args, newbits...)
+	return CidrSubnetsFunc.Call(args)
+}
+
 // RDnsHost reverses the octets of an IPv4 address or network.
 func RDnsHost(prefix cty.Value) (cty.Value, error) {
 	return RDnsHostFunc.Call([]cty.Value{prefix})
 }
+
+// IPFromRDns parses a reverse-DNS host name back into the IPv4 or IPv6
+// address it names.
+func IPFromRDns(name cty.Value) (cty.Value, error) {
+	return IPFromRDnsFunc.Call([]cty.Value{name})
+}
+
+// RDnsZone returns the reverse-DNS zone names needed to delegate PTR
+// records for a given CIDR prefix.
+func RDnsZone(prefix cty.Value) (cty.Value, error) {
+	return RDnsZoneFunc.Call([]cty.Value{prefix})
+}
+
+// CidrContains checks whether a given IP address or CIDR prefix is entirely
+// contained within another CIDR prefix.
+func CidrContains(prefix, ip cty.Value) (cty.Value, error) {
+	return CidrContainsFunc.Call([]cty.Value{prefix, ip})
+}
+
+// CidrOverlap checks whether two given CIDR prefixes share any addresses
+// in common.
+func CidrOverlap(a, b cty.Value) (cty.Value, error) {
+	return CidrOverlapFunc.Call([]cty.Value{a, b})
+}
+
+// CidrMerge coalesces a list of IP address prefixes in CIDR notation into
+// the minimal set of prefixes that describes the same addresses.
+func CidrMerge(prefixes cty.Value) (cty.Value, error) {
+	return CidrMergeFunc.Call([]cty.Value{prefixes})
+}
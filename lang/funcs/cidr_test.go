@@ -7,6 +7,16 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
+// mustParseNumber parses a decimal string into a cty.Number, for test cases
+// whose netnum values are too large to express with NumberIntVal's int64.
+func mustParseNumber(s string) cty.Value {
+	v, err := cty.ParseNumberVal(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 func TestCidrHost(t *testing.T) {
 	tests := []struct {
 		Prefix  cty.Value
@@ -193,6 +203,27 @@ func TestCidrSubnet(t *testing.T) {
 			cty.StringVal("fe80:0:0:6::/64"),
 			true,
 		},
+		{ // netnum beyond the 32-bit range that a machine int could hold on some platforms
+			cty.StringVal("2001:db8::/32"),
+			cty.NumberIntVal(32),
+			cty.NumberIntVal(4000000000),
+			cty.StringVal("2001:db8:ee6b:2800::/64"),
+			false,
+		},
+		{ // netnum too large to fit in an int64 at all; must go through math/big
+			cty.StringVal("2001:db8::/32"),
+			cty.NumberIntVal(96),
+			mustParseNumber("1180591620717411304658"),
+			cty.StringVal("2001:db8:0:40::4d2/128"),
+			false,
+		},
+		{ // netnum doesn't fit in newbits bits
+			cty.StringVal("2001:db8::/32"),
+			cty.NumberIntVal(8),
+			cty.NumberIntVal(256),
+			cty.UnknownVal(cty.String),
+			true,
+		},
 	}
 
 	for _, test := range tests {
@@ -215,6 +246,330 @@ func TestCidrSubnet(t *testing.T) {
 	}
 }
 
+func TestCidrCanonical(t *testing.T) {
+	tests := []struct {
+		Prefix cty.Value
+		Want   cty.Value
+		Err    bool
+	}{
+		{
+			cty.StringVal("192.168.1.5/24"),
+			cty.StringVal("192.168.1.0/24"),
+			false,
+		},
+		{
+			cty.StringVal("1::/64"),
+			cty.StringVal("1::/64"),
+			false,
+		},
+		{
+			cty.StringVal("fe80:0:0:6::/64"),
+			cty.StringVal("fe80:0:0:6::/64"),
+			false,
+		},
+		{ // an IPv4-mapped IPv6 prefix normalizes to plain IPv4
+			cty.StringVal("::ffff:192.168.0.0/112"),
+			cty.StringVal("192.168.0.0/16"),
+			false,
+		},
+		{
+			cty.StringVal("not-a-cidr"),
+			cty.UnknownVal(cty.String),
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidrcanonical(%#v)", test.Prefix), func(t *testing.T) {
+			got, err := CidrCanonical(test.Prefix)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+
+			// Re-running cidrcanonical against its own output must be a
+			// no-op: canonical form is a fixed point.
+			again, err := CidrCanonical(got)
+			if err != nil {
+				t.Fatalf("unexpected error on round-trip: %s", err)
+			}
+			if !again.RawEquals(got) {
+				t.Errorf("canonical form is not a fixed point\ngot:  %#v\nwant: %#v", again, got)
+			}
+		})
+	}
+}
+
+func TestCidrSubnets(t *testing.T) {
+	tests := []struct {
+		Prefix  cty.Value
+		Newbits []cty.Value
+		Want    cty.Value
+		Err     bool
+	}{
+		{
+			cty.StringVal("10.0.0.0/16"),
+			[]cty.Value{cty.NumberIntVal(8), cty.NumberIntVal(8)},
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/24"),
+				cty.StringVal("10.0.1.0/24"),
+			}),
+			false,
+		},
+		{ // differently-sized subnets get left-aligned to their own boundary
+			cty.StringVal("10.0.0.0/16"),
+			[]cty.Value{cty.NumberIntVal(2), cty.NumberIntVal(4), cty.NumberIntVal(4)},
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/18"),
+				cty.StringVal("10.0.64.0/20"),
+				cty.StringVal("10.0.80.0/20"),
+			}),
+			false,
+		},
+		{ // a newbits of 0 just returns the parent prefix unchanged
+			cty.StringVal("10.0.0.0/24"),
+			[]cty.Value{cty.NumberIntVal(0)},
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/24"),
+			}),
+			false,
+		},
+		{
+			cty.StringVal("fe80::/48"),
+			[]cty.Value{cty.NumberIntVal(16), cty.NumberIntVal(16)},
+			cty.ListVal([]cty.Value{
+				cty.StringVal("fe80::/64"),
+				cty.StringVal("fe80:0:0:1::/64"),
+			}),
+			false,
+		},
+		{ // doesn't fit in the parent prefix
+			cty.StringVal("10.0.0.0/30"),
+			[]cty.Value{cty.NumberIntVal(4), cty.NumberIntVal(4)},
+			cty.UnknownVal(cty.List(cty.String)),
+			true,
+		},
+		{ // not a valid CIDR mask
+			cty.StringVal("not-a-cidr"),
+			[]cty.Value{cty.NumberIntVal(4)},
+			cty.UnknownVal(cty.List(cty.String)),
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidrsubnets(%#v, %#v)", test.Prefix, test.Newbits), func(t *testing.T) {
+			got, err := CidrSubnets(test.Prefix, test.Newbits...)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestCidrContains(t *testing.T) {
+	tests := []struct {
+		Prefix cty.Value
+		IP     cty.Value
+		Want   cty.Value
+		Err    bool
+	}{
+		{
+			cty.StringVal("192.168.0.0/16"),
+			cty.StringVal("192.168.1.5"),
+			cty.True,
+			false,
+		},
+		{
+			cty.StringVal("192.168.0.0/16"),
+			cty.StringVal("192.168.1.0/24"),
+			cty.True,
+			false,
+		},
+		{
+			cty.StringVal("192.168.1.0/24"),
+			cty.StringVal("192.168.0.0/16"),
+			cty.False,
+			false,
+		},
+		{
+			cty.StringVal("10.0.0.0/8"),
+			cty.StringVal("192.168.1.5"),
+			cty.False,
+			false,
+		},
+		{
+			cty.StringVal("2001:db8::/32"),
+			cty.StringVal("2001:db8:1::1"),
+			cty.True,
+			false,
+		},
+		{
+			cty.StringVal("192.168.0.0/16"),
+			cty.StringVal("2001:db8::1"),
+			cty.UnknownVal(cty.Bool),
+			true, // can't compare IPv4 with IPv6
+		},
+		{
+			cty.StringVal("not-a-cidr"),
+			cty.StringVal("192.168.1.5"),
+			cty.UnknownVal(cty.Bool),
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidrcontains(%#v, %#v)", test.Prefix, test.IP), func(t *testing.T) {
+			got, err := CidrContains(test.Prefix, test.IP)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestCidrOverlap(t *testing.T) {
+	tests := []struct {
+		A    cty.Value
+		B    cty.Value
+		Want cty.Value
+		Err  bool
+	}{
+		{
+			cty.StringVal("192.168.0.0/24"),
+			cty.StringVal("192.168.0.128/25"),
+			cty.True,
+			false,
+		},
+		{
+			cty.StringVal("192.168.0.0/24"),
+			cty.StringVal("192.168.1.0/24"),
+			cty.False,
+			false,
+		},
+		{
+			cty.StringVal("10.0.0.0/8"),
+			cty.StringVal("10.1.0.0/16"),
+			cty.True,
+			false,
+		},
+		{
+			cty.StringVal("192.168.0.0/24"),
+			cty.StringVal("2001:db8::/32"),
+			cty.UnknownVal(cty.Bool),
+			true, // can't compare IPv4 with IPv6
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidroverlap(%#v, %#v)", test.A, test.B), func(t *testing.T) {
+			got, err := CidrOverlap(test.A, test.B)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestCidrMerge(t *testing.T) {
+	tests := []struct {
+		Prefixes cty.Value
+		Want     cty.Value
+		Err      bool
+	}{
+		{
+			cty.ListValEmpty(cty.String),
+			cty.ListValEmpty(cty.String),
+			false,
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("192.168.0.0/24"),
+				cty.StringVal("192.168.1.0/24"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("192.168.0.0/23"),
+			}),
+			false,
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("192.168.0.0/24"),
+				cty.StringVal("192.168.0.0/25"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("192.168.0.0/24"),
+			}),
+			false,
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("not-a-cidr"),
+			}),
+			cty.UnknownVal(cty.List(cty.String)),
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidrmerge(%#v)", test.Prefixes), func(t *testing.T) {
+			got, err := CidrMerge(test.Prefixes)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
 func TestRDnsReverse(t *testing.T) {
 	tests := []struct {
 		Address cty.Value
@@ -267,3 +622,121 @@ func TestRDnsReverse(t *testing.T) {
 		})
 	}
 }
+
+func TestIPFromRDns(t *testing.T) {
+	tests := []struct {
+		Name cty.Value
+		Want cty.Value
+		Err  bool
+	}{
+		{
+			cty.StringVal("1.1.168.192.in-addr.arpa."),
+			cty.StringVal("192.168.1.1"),
+			false,
+		},
+		{
+			cty.StringVal("1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."),
+			cty.StringVal("2001:db8::1"),
+			false,
+		},
+		{ // the trailing dot is optional
+			cty.StringVal("1.1.168.192.in-addr.arpa"),
+			cty.StringVal("192.168.1.1"),
+			false,
+		},
+		{ // not a full in-addr.arpa name
+			cty.StringVal("1.168.192.in-addr.arpa."),
+			cty.UnknownVal(cty.String),
+			true,
+		},
+		{
+			cty.StringVal("not-a-reverse-dns-name"),
+			cty.UnknownVal(cty.String),
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("ipfromrdns(%#v)", test.Name), func(t *testing.T) {
+			got, err := IPFromRDns(test.Name)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestRDnsZone(t *testing.T) {
+	tests := []struct {
+		Prefix cty.Value
+		Want   cty.Value
+		Err    bool
+	}{
+		{ // octet-aligned: a single zone
+			cty.StringVal("10.0.0.0/24"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("0.0.10.in-addr.arpa."),
+			}),
+			false,
+		},
+		{ // not octet-aligned: four /24 zones cover the /22
+			cty.StringVal("10.0.0.0/22"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("0.0.10.in-addr.arpa."),
+				cty.StringVal("1.0.10.in-addr.arpa."),
+				cty.StringVal("2.0.10.in-addr.arpa."),
+				cty.StringVal("3.0.10.in-addr.arpa."),
+			}),
+			false,
+		},
+		{ // nibble-aligned: a single zone
+			cty.StringVal("2001:db8::/32"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("8.b.d.0.1.0.0.2.ip6.arpa."),
+			}),
+			false,
+		},
+		{ // not nibble-aligned: two zones cover the /35
+			cty.StringVal("2001:db8::/35"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("0.8.b.d.0.1.0.0.2.ip6.arpa."),
+				cty.StringVal("1.8.b.d.0.1.0.0.2.ip6.arpa."),
+			}),
+			false,
+		},
+		{
+			cty.StringVal("not-a-cidr"),
+			cty.UnknownVal(cty.List(cty.String)),
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("rdnszone(%#v)", test.Prefix), func(t *testing.T) {
+			got, err := RDnsZone(test.Prefix)
+
+			if test.Err {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}